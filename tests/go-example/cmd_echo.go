@@ -0,0 +1,24 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+var cmdEcho = &Command{
+	Name:      "echo",
+	UsageLine: "echo [args...]",
+	Short:     "echo the remaining arguments",
+	Long: `Echo prints its arguments, space-separated, followed by a newline.
+
+It exists mainly as a trivial example subcommand.`,
+	Run: runEcho,
+}
+
+func init() {
+	register(cmdEcho)
+}
+
+func runEcho(cmd *Command, args []string) {
+	fmt.Println(strings.Join(args, " "))
+}
@@ -0,0 +1,172 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	goexec "go-example/internal/exec"
+)
+
+var (
+	fmtAll   bool
+	fmtWrite bool
+	fmtDiff  bool
+)
+
+// runner is the exec.Runner used to shell out to git and gofmt; tests
+// replace it with a fake.
+var runner goexec.Runner = goexec.System{}
+
+var cmdFmt = &Command{
+	Name:      "fmt",
+	UsageLine: "fmt [-all] [-write] [-diff] [files...]",
+	Short:     "check (or fix) gofmt formatting",
+	Long: `Fmt runs gofmt over a set of Go files and reports any that
+aren't formatted, so it can be used as a pre-commit hook.
+
+The files to check are, in order of preference: the positional
+arguments; with -all, every .go file found by walking the current
+directory; otherwise, the files changed in the current git repo
+("git diff --name-only --diff-filter=ACMR").
+
+By default fmt lists unformatted files and exits non-zero. -write
+rewrites them in place with "gofmt -w". -diff prints a unified diff of
+the changes gofmt would make, without writing anything.`,
+	Run: runFmt,
+}
+
+func init() {
+	cmdFmt.Flag.BoolVar(&fmtAll, "all", false, "walk the whole tree instead of using git diff")
+	cmdFmt.Flag.BoolVar(&fmtWrite, "write", false, "rewrite unformatted files in place")
+	cmdFmt.Flag.BoolVar(&fmtDiff, "diff", false, "print a unified diff instead of listing files")
+	register(cmdFmt)
+}
+
+func runFmt(cmd *Command, args []string) {
+	ctx := context.Background()
+
+	files, err := fmtFiles(ctx, args)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "go-example fmt:", err)
+		os.Exit(1)
+	}
+	if len(files) == 0 {
+		fmt.Println("no Go files to check")
+		return
+	}
+
+	if fmtWrite {
+		if _, err := runner.Output(ctx, "gofmt", append([]string{"-w"}, files...)...); err != nil {
+			fmt.Fprintln(os.Stderr, "go-example fmt:", describeExecErr(err))
+			os.Exit(1)
+		}
+		return
+	}
+
+	if fmtDiff {
+		out, err := runner.Output(ctx, "gofmt", append([]string{"-d"}, files...)...)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "go-example fmt:", describeExecErr(err))
+			os.Exit(1)
+		}
+		os.Stdout.Write(out)
+		return
+	}
+
+	out, err := runner.Output(ctx, "gofmt", append([]string{"-l"}, files...)...)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "go-example fmt:", describeExecErr(err))
+		os.Exit(1)
+	}
+
+	unformatted := strings.Fields(string(out))
+	if len(unformatted) == 0 {
+		return
+	}
+
+	fmt.Fprint(os.Stderr, unformattedMessage(unformatted))
+	os.Exit(1)
+}
+
+// unformattedMessage renders the listing printed when files aren't
+// gofmt'd: the files themselves, followed by a ready-to-copy
+// "gofmt -w \" continuation-style command to fix them.
+func unformattedMessage(files []string) string {
+	var b strings.Builder
+	fmt.Fprintln(&b, "the following files are not gofmt'd:")
+	for _, f := range files {
+		fmt.Fprintf(&b, "  %s\n", f)
+	}
+	fmt.Fprintln(&b, "\nfix with:")
+	fmt.Fprint(&b, "  gofmt -w \\\n")
+	for i, f := range files {
+		sep := " \\"
+		if i == len(files)-1 {
+			sep = ""
+		}
+		fmt.Fprintf(&b, "    %s%s\n", f, sep)
+	}
+	return b.String()
+}
+
+// fmtFiles resolves the set of files fmt should check, in order of
+// preference: explicit args, (with -all) a directory walk, or git diff.
+func fmtFiles(ctx context.Context, args []string) ([]string, error) {
+	if len(args) > 0 {
+		return args, nil
+	}
+
+	if fmtAll {
+		return walkGoFiles(".")
+	}
+
+	out, err := runner.Output(ctx, "git", "diff", "--name-only", "--diff-filter=ACMR")
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return nil, fmt.Errorf("not a git repo and -all not given: %w", err)
+		}
+		return nil, err
+	}
+
+	var files []string
+	for _, f := range strings.Fields(string(out)) {
+		if strings.HasSuffix(f, ".go") {
+			files = append(files, f)
+		}
+	}
+	return files, nil
+}
+
+func walkGoFiles(root string) ([]string, error) {
+	var files []string
+	err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			if info.Name() == ".git" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if strings.HasSuffix(path, ".go") {
+			files = append(files, path)
+		}
+		return nil
+	})
+	return files, err
+}
+
+func describeExecErr(err error) string {
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && len(exitErr.Stderr) > 0 {
+		return strings.TrimSpace(string(exitErr.Stderr))
+	}
+	return err.Error()
+}
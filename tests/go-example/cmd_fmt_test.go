@@ -0,0 +1,173 @@
+package main
+
+import (
+	"context"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	goexec "go-example/internal/exec"
+)
+
+// fakeRunner stubs goexec.Runner for tests: it records every call and
+// returns canned output/errors keyed by "name arg1 arg2 ...".
+type fakeRunner struct {
+	calls  [][]string
+	output map[string][]byte
+	err    map[string]error
+}
+
+func (f *fakeRunner) Output(ctx context.Context, name string, args ...string) ([]byte, error) {
+	key := strings.Join(append([]string{name}, args...), " ")
+	f.calls = append(f.calls, append([]string{name}, args...))
+	if err, ok := f.err[key]; ok {
+		return nil, err
+	}
+	return f.output[key], nil
+}
+
+// withFmtState saves and restores the package-level fmt flags and runner
+// so tests can set them without leaking state into other tests.
+func withFmtState(t *testing.T, r goexec.Runner, all bool) {
+	t.Helper()
+	origRunner, origAll, origWrite, origDiff := runner, fmtAll, fmtWrite, fmtDiff
+	runner, fmtAll, fmtWrite, fmtDiff = r, all, false, false
+	t.Cleanup(func() {
+		runner, fmtAll, fmtWrite, fmtDiff = origRunner, origAll, origWrite, origDiff
+	})
+}
+
+func TestFmtFilesExplicitArgs(t *testing.T) {
+	withFmtState(t, &fakeRunner{}, false)
+
+	got, err := fmtFiles(context.Background(), []string{"a.go", "b.go"})
+	if err != nil {
+		t.Fatalf("fmtFiles: %v", err)
+	}
+	want := []string{"a.go", "b.go"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("fmtFiles = %v, want %v", got, want)
+	}
+}
+
+func TestFmtFilesGitDiff(t *testing.T) {
+	r := &fakeRunner{output: map[string][]byte{
+		"git diff --name-only --diff-filter=ACMR": []byte("a.go\nREADME.md\nc.go\n"),
+	}}
+	withFmtState(t, r, false)
+
+	got, err := fmtFiles(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("fmtFiles: %v", err)
+	}
+	want := []string{"a.go", "c.go"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("fmtFiles = %v, want %v (non-.go files should be filtered out)", got, want)
+	}
+}
+
+func TestFmtFilesNotGitRepo(t *testing.T) {
+	// A real *exec.ExitError, as git would return if run outside a repo.
+	exitErr := exec.Command("sh", "-c", "exit 1").Run()
+	if exitErr == nil {
+		t.Fatal("expected exec.Command to fail")
+	}
+
+	r := &fakeRunner{err: map[string]error{
+		"git diff --name-only --diff-filter=ACMR": exitErr,
+	}}
+	withFmtState(t, r, false)
+
+	_, err := fmtFiles(context.Background(), nil)
+	if err == nil || !strings.Contains(err.Error(), "not a git repo") {
+		t.Fatalf("fmtFiles error = %v, want a \"not a git repo\" error", err)
+	}
+}
+
+func TestFmtFilesAllWalksTree(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "a.go"), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(dir, "sub"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "sub", "b.go"), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "notes.txt"), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := walkGoFiles(dir)
+	if err != nil {
+		t.Fatalf("walkGoFiles: %v", err)
+	}
+	if len(got) != 2 {
+		t.Errorf("walkGoFiles returned %v, want exactly the 2 .go files", got)
+	}
+}
+
+func TestUnformattedMessage(t *testing.T) {
+	msg := unformattedMessage([]string{"a.go", "b.go"})
+
+	for _, want := range []string{"a.go", "b.go", "gofmt -w \\", "a.go \\", "b.go\n"} {
+		if !strings.Contains(msg, want) {
+			t.Errorf("unformattedMessage() missing %q, got:\n%s", want, msg)
+		}
+	}
+}
+
+func TestRunFmtWriteInvokesGofmtW(t *testing.T) {
+	r := &fakeRunner{}
+	withFmtState(t, r, false)
+	fmtWrite = true
+
+	runFmt(cmdFmt, []string{"a.go"})
+
+	if len(r.calls) != 1 || strings.Join(r.calls[0], " ") != "gofmt -w a.go" {
+		t.Errorf("calls = %v, want a single \"gofmt -w a.go\" call", r.calls)
+	}
+}
+
+func TestRunFmtDiffPrintsGofmtOutput(t *testing.T) {
+	r := &fakeRunner{output: map[string][]byte{
+		"gofmt -d a.go": []byte("--- a.go\n+++ a.go\n"),
+	}}
+	withFmtState(t, r, false)
+	fmtDiff = true
+
+	out := captureStdout(t, func() {
+		runFmt(cmdFmt, []string{"a.go"})
+	})
+
+	if out != "--- a.go\n+++ a.go\n" {
+		t.Errorf("stdout = %q, want the gofmt -d output verbatim", out)
+	}
+}
+
+// captureStdout redirects os.Stdout for the duration of fn and returns
+// whatever was written to it.
+func captureStdout(t *testing.T, fn func()) string {
+	t.Helper()
+	orig := os.Stdout
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	os.Stdout = w
+
+	fn()
+
+	w.Close()
+	os.Stdout = orig
+
+	out, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(out)
+}
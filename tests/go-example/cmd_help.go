@@ -0,0 +1,65 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+var cmdHelp = &Command{
+	Name:      "help",
+	UsageLine: "help [command]",
+	Short:     "show help for a command",
+	Long: `Help shows usage information for go-example itself, or, given a
+command name, the full help text for that command.`,
+	Run: runHelp,
+}
+
+func init() {
+	register(cmdHelp)
+}
+
+func runHelp(cmd *Command, args []string) {
+	if len(args) == 0 {
+		printUsage(os.Stdout)
+		return
+	}
+	if len(args) != 1 {
+		fmt.Fprintln(os.Stderr, "usage: go-example help [command]")
+		os.Exit(1)
+	}
+
+	name := args[0]
+	target := lookupCommand(name)
+	if target == nil {
+		fmt.Fprintf(os.Stderr, "go-example help %s: unknown command\n", name)
+		if s := suggestCommand(name); s != "" {
+			fmt.Fprintf(os.Stderr, "Did you mean %q?\n", s)
+		}
+		os.Exit(1)
+	}
+
+	fmt.Printf("usage: go-example %s\n\n", target.UsageLine)
+	fmt.Println(strings.TrimRight(target.Long, "\n"))
+}
+
+// printUsage writes the top-level usage summary: one line per registered
+// command, name and Short description aligned into two columns.
+func printUsage(w *os.File) {
+	fmt.Fprintln(w, "Usage: go-example <command> [arguments]")
+	fmt.Fprintln(w, "")
+	fmt.Fprintln(w, "Commands:")
+
+	width := 0
+	for _, cmd := range commands {
+		if len(cmd.Name) > width {
+			width = len(cmd.Name)
+		}
+	}
+	for _, cmd := range commands {
+		fmt.Fprintf(w, "  %-*s  %s\n", width, cmd.Name, cmd.Short)
+	}
+
+	fmt.Fprintln(w, "")
+	fmt.Fprintln(w, `Use "go-example help <command>" for more information about a command.`)
+}
@@ -0,0 +1,202 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+)
+
+// errExitCleanly is returned by a setup step when the user has chosen to
+// back out of setup (e.g. declining to overwrite an existing config).
+// runSetup treats it as a clean exit(0) rather than a failure.
+var errExitCleanly = errors.New("setup: exit cleanly")
+
+var setupYes bool
+
+var cmdSetup = &Command{
+	Name:      "setup",
+	UsageLine: "setup [-y]",
+	Short:     "interactively configure go-example",
+	Long: `Setup walks through first-run configuration: it looks for an
+existing config file, asks where to install to, confirms the choices,
+and writes them to $XDG_CONFIG_HOME/go-example/config.toml.
+
+The -y/--yes flag accepts the default answer to every question instead
+of prompting, for non-interactive or CI use.`,
+	Run: runSetup,
+}
+
+func init() {
+	cmdSetup.Flag.BoolVar(&setupYes, "y", false, "accept defaults without prompting")
+	cmdSetup.Flag.BoolVar(&setupYes, "yes", false, "accept defaults without prompting")
+	register(cmdSetup)
+}
+
+// setupState accumulates the answers gathered across steps.
+type setupState struct {
+	configPath string
+	installDir string
+	stdin      *bufio.Reader
+}
+
+// step is a single stage of the setup pipeline. It returns errExitCleanly
+// to end setup without error, e.g. because the user declined to continue.
+type step func(ctx context.Context) error
+
+func runSetup(cmd *Command, args []string) {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	// prompt's stdin read can't be canceled directly, so once the user
+	// hits Ctrl-C, close stdin ourselves to unblock it rather than
+	// leaving that goroutine parked on the read forever.
+	go func() {
+		<-ctx.Done()
+		os.Stdin.Close()
+	}()
+
+	configDir := os.Getenv("XDG_CONFIG_HOME")
+	if configDir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "go-example setup:", err)
+			os.Exit(1)
+		}
+		configDir = filepath.Join(home, ".config")
+	}
+
+	state := &setupState{
+		configPath: filepath.Join(configDir, "go-example", "config.toml"),
+		installDir: filepath.Join(configDir, "go-example", "bin"),
+		stdin:      bufio.NewReader(os.Stdin),
+	}
+
+	steps := []step{
+		state.welcome,
+		state.detectExisting,
+		state.chooseInstallDir,
+		state.confirm,
+		state.write,
+	}
+
+	for _, s := range steps {
+		if err := s(ctx); err != nil {
+			if errors.Is(err, errExitCleanly) {
+				return
+			}
+			fmt.Fprintln(os.Stderr, "go-example setup:", err)
+			os.Exit(1)
+		}
+	}
+}
+
+func (s *setupState) welcome(ctx context.Context) error {
+	fmt.Println("Welcome to go-example setup.")
+	return nil
+}
+
+func (s *setupState) detectExisting(ctx context.Context) error {
+	if _, err := os.Stat(s.configPath); err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+
+	answer, err := prompt(ctx, s.stdin, fmt.Sprintf("Found existing config at %s, overwrite?", s.configPath), "y")
+	if err != nil {
+		return err
+	}
+	if !isYes(answer) {
+		fmt.Println("Keeping existing config, exiting.")
+		return errExitCleanly
+	}
+	return nil
+}
+
+func (s *setupState) chooseInstallDir(ctx context.Context) error {
+	answer, err := prompt(ctx, s.stdin, "Install directory", s.installDir)
+	if err != nil {
+		return err
+	}
+	s.installDir = answer
+	return nil
+}
+
+func (s *setupState) confirm(ctx context.Context) error {
+	fmt.Printf("\nAbout to write:\n  config:  %s\n  install: %s\n\n", s.configPath, s.installDir)
+	answer, err := prompt(ctx, s.stdin, "Proceed?", "y")
+	if err != nil {
+		return err
+	}
+	if !isYes(answer) {
+		fmt.Println("Aborted, nothing written.")
+		return errExitCleanly
+	}
+	return nil
+}
+
+func (s *setupState) write(ctx context.Context) error {
+	if err := os.MkdirAll(filepath.Dir(s.configPath), 0o755); err != nil {
+		return err
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "install_dir = %q\n", s.installDir)
+
+	if err := os.WriteFile(s.configPath, []byte(b.String()), 0o644); err != nil {
+		return err
+	}
+	fmt.Printf("Wrote %s\n", s.configPath)
+	return nil
+}
+
+// prompt asks question on stdout, offering defaultAnswer, and reads a
+// line of response from r. An empty response keeps the default. It
+// returns ctx.Err() if ctx is canceled before a line is read, and honors
+// -y/--yes by returning the default without reading from r at all.
+func prompt(ctx context.Context, r *bufio.Reader, question, defaultAnswer string) (string, error) {
+	if setupYes {
+		return defaultAnswer, nil
+	}
+
+	fmt.Printf("%s [%s] ", question, defaultAnswer)
+
+	type result struct {
+		line string
+		err  error
+	}
+	done := make(chan result, 1)
+	go func() {
+		line, err := r.ReadString('\n')
+		done <- result{line, err}
+	}()
+
+	select {
+	case <-ctx.Done():
+		return "", ctx.Err()
+	case res := <-done:
+		if res.err != nil && res.line == "" {
+			return "", res.err
+		}
+		line := strings.TrimSpace(res.line)
+		if line == "" {
+			return defaultAnswer, nil
+		}
+		return line, nil
+	}
+}
+
+func isYes(answer string) bool {
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return true
+	default:
+		return false
+	}
+}
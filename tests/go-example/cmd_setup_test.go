@@ -0,0 +1,136 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// blockingReader blocks Read until unblock is closed, then returns EOF
+// and closes returned — used to prove a goroutine reading from it
+// actually completes once something (e.g. closing stdin) unblocks it.
+type blockingReader struct {
+	unblock  chan struct{}
+	returned chan struct{}
+}
+
+func newBlockingReader() *blockingReader {
+	return &blockingReader{unblock: make(chan struct{}), returned: make(chan struct{})}
+}
+
+func (r *blockingReader) Read(p []byte) (int, error) {
+	<-r.unblock
+	close(r.returned)
+	return 0, io.EOF
+}
+
+func TestPromptReturnsPromptlyOnCanceledContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	r := newBlockingReader()
+	br := bufio.NewReader(r)
+
+	start := time.Now()
+	answer, err := prompt(ctx, br, "question", "default")
+	elapsed := time.Since(start)
+
+	if elapsed > 200*time.Millisecond {
+		t.Fatalf("prompt took %s to return on an already-canceled context, want near-instant", elapsed)
+	}
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("err = %v, want context.Canceled", err)
+	}
+	if answer != "" {
+		t.Fatalf("answer = %q, want empty on cancellation", answer)
+	}
+
+	// The read prompt spawned is still blocked on r.Read; simulate what
+	// runSetup's watcher does (closing stdin) and confirm the goroutine
+	// actually unblocks instead of leaking forever.
+	close(r.unblock)
+	select {
+	case <-r.returned:
+	case <-time.After(time.Second):
+		t.Fatal("reader goroutine never returned from Read after unblocking; it leaked")
+	}
+}
+
+func TestIsYes(t *testing.T) {
+	tests := []struct {
+		in   string
+		want bool
+	}{
+		{"y", true},
+		{"Y", true},
+		{"yes", true},
+		{"YES", true},
+		{" yes ", true},
+		{"n", false},
+		{"no", false},
+		{"", false},
+		{"maybe", false},
+	}
+	for _, tt := range tests {
+		if got := isYes(tt.in); got != tt.want {
+			t.Errorf("isYes(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestSetupStateWrite(t *testing.T) {
+	dir := t.TempDir()
+	s := &setupState{
+		configPath: filepath.Join(dir, "go-example", "config.toml"),
+		installDir: "/opt/go-example/bin",
+	}
+
+	if err := s.write(context.Background()); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	data, err := os.ReadFile(s.configPath)
+	if err != nil {
+		t.Fatalf("reading written config: %v", err)
+	}
+	want := `install_dir = "/opt/go-example/bin"` + "\n"
+	if string(data) != want {
+		t.Errorf("config.toml = %q, want %q", data, want)
+	}
+}
+
+func TestDetectExistingDeclineExitsCleanly(t *testing.T) {
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "config.toml")
+	if err := os.WriteFile(configPath, []byte("install_dir = \"old\"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	origYes := setupYes
+	setupYes = false
+	t.Cleanup(func() { setupYes = origYes })
+
+	s := &setupState{
+		configPath: configPath,
+		stdin:      bufio.NewReader(strings.NewReader("n\n")),
+	}
+
+	err := s.detectExisting(context.Background())
+	if !errors.Is(err, errExitCleanly) {
+		t.Fatalf("detectExisting with a declined prompt returned %v, want errExitCleanly", err)
+	}
+}
+
+func TestDetectExistingNoConfigIsNoop(t *testing.T) {
+	s := &setupState{configPath: filepath.Join(t.TempDir(), "missing", "config.toml")}
+
+	if err := s.detectExisting(context.Background()); err != nil {
+		t.Fatalf("detectExisting with no existing config returned %v, want nil", err)
+	}
+}
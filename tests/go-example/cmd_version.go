@@ -0,0 +1,68 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"go-example/internal/version"
+)
+
+var versionOutput string
+
+var cmdVersion = &Command{
+	Name:      "version",
+	UsageLine: "version [--output=text|json|yaml|short]",
+	Short:     "print version information",
+	Long: `Version prints build metadata for the running binary: semantic
+version, git commit and commit date, build date, whether the build tree
+was dirty, and the Go toolchain and platform used to build it.
+
+The --output flag selects the format: "text" (default, multi-line and
+human-readable), "json", "yaml", or "short" (a single "name version
+(commit)" line).`,
+	Run: runVersion,
+}
+
+func init() {
+	cmdVersion.Flag.StringVar(&versionOutput, "output", "text", "output format: text, json, yaml, short")
+	register(cmdVersion)
+}
+
+func runVersion(cmd *Command, args []string) {
+	info := version.Get()
+	switch versionOutput {
+	case "json":
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(info); err != nil {
+			fmt.Fprintln(os.Stderr, "error encoding version as json:", err)
+			os.Exit(1)
+		}
+	case "yaml":
+		fmt.Print(toYAML(info))
+	case "short":
+		fmt.Println(info.Short())
+	case "text":
+		fmt.Print(info.String())
+	default:
+		fmt.Fprintf(os.Stderr, "go-example version: unknown --output %q\n", versionOutput)
+		os.Exit(1)
+	}
+}
+
+// toYAML renders Info as flat YAML. Hand-rolled rather than pulling in a
+// dependency, since the struct is small and entirely flat.
+func toYAML(i version.Info) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "version: %s\n", i.Version)
+	fmt.Fprintf(&b, "commit: %s\n", i.Commit)
+	fmt.Fprintf(&b, "commitDate: %s\n", i.CommitDate)
+	fmt.Fprintf(&b, "buildDate: %s\n", i.BuildDate)
+	fmt.Fprintf(&b, "dirty: %t\n", i.Dirty)
+	fmt.Fprintf(&b, "goVersion: %s\n", i.GoVersion)
+	fmt.Fprintf(&b, "compiler: %s\n", i.Compiler)
+	fmt.Fprintf(&b, "platform: %s\n", i.Platform)
+	return b.String()
+}
@@ -0,0 +1,47 @@
+package main
+
+import "flag"
+
+// Command is a single go-example subcommand, modeled on the Command type
+// used by cmd/go: a registry of these drives both dispatch and --help.
+type Command struct {
+	// Name is the subcommand's name as typed on the command line, e.g. "echo".
+	Name string
+
+	// UsageLine is the one-line usage summary, e.g. "echo [args...]".
+	UsageLine string
+
+	// Short is a one-line description shown in the top-level help listing.
+	Short string
+
+	// Long is the full description shown by "help <command>".
+	Long string
+
+	// Flag is the flag set used by Run; commands that don't need flags
+	// can leave this at its zero value.
+	Flag flag.FlagSet
+
+	// Run runs the command. args is the set of arguments after the
+	// command name, with any flags in Flag already parsed out by main.
+	Run func(cmd *Command, args []string)
+}
+
+// commands is the registry of all known subcommands, populated by each
+// command's package-level init via register.
+var commands []*Command
+
+// register adds cmd to the registry. Commands call this from an init
+// func in their own file.
+func register(cmd *Command) {
+	commands = append(commands, cmd)
+}
+
+// lookupCommand returns the registered command named name, or nil.
+func lookupCommand(name string) *Command {
+	for _, cmd := range commands {
+		if cmd.Name == name {
+			return cmd
+		}
+	}
+	return nil
+}
@@ -0,0 +1,22 @@
+package main
+
+import "testing"
+
+func TestRegisterAndLookupCommand(t *testing.T) {
+	withCommands(t)
+
+	cmd := &Command{Name: "widget"}
+	register(cmd)
+
+	if got := lookupCommand("widget"); got != cmd {
+		t.Errorf("lookupCommand(%q) = %v, want %v", "widget", got, cmd)
+	}
+}
+
+func TestLookupCommandUnknown(t *testing.T) {
+	withCommands(t, "echo")
+
+	if got := lookupCommand("nope"); got != nil {
+		t.Errorf("lookupCommand(%q) = %v, want nil", "nope", got)
+	}
+}
@@ -0,0 +1,23 @@
+// Package exec shims the handful of external commands go-example shells
+// out to (git, gofmt) behind a small interface, so callers can be tested
+// with a fake Runner instead of invoking real processes.
+package exec
+
+import (
+	"context"
+	"os/exec"
+)
+
+// Runner runs an external command and returns its standard output.
+type Runner interface {
+	Output(ctx context.Context, name string, args ...string) ([]byte, error)
+}
+
+// System is the default Runner, backed by os/exec.
+type System struct{}
+
+// Output runs name with args and returns its standard output. Non-zero
+// exits are reported as *exec.ExitError, same as os/exec.
+func (System) Output(ctx context.Context, name string, args ...string) ([]byte, error) {
+	return exec.CommandContext(ctx, name, args...).Output()
+}
@@ -0,0 +1,123 @@
+// Package version exposes build metadata for the go-example binary.
+//
+// The fields below are populated in two ways: when the binary is built
+// with `go build -ldflags "-X ..."`, the linker overwrites the package
+// vars directly; otherwise Get falls back to the information Go's
+// toolchain embeds automatically via runtime/debug.ReadBuildInfo, so
+// `go install` users still see a useful commit SHA and dirty flag.
+package version
+
+import (
+	"fmt"
+	"runtime"
+	"runtime/debug"
+)
+
+// These are intended to be set at build time, e.g.:
+//
+//	go build -ldflags "-X go-example/internal/version.version=1.2.3 \
+//	    -X go-example/internal/version.commit=$(git rev-parse HEAD) \
+//	    -X go-example/internal/version.commitDate=$(git log -1 --format=%cI) \
+//	    -X go-example/internal/version.buildDate=$(date -u +%Y-%m-%dT%H:%M:%SZ)"
+var (
+	version    = "dev"
+	commit     = ""
+	commitDate = ""
+	buildDate  = ""
+	dirty      = ""
+)
+
+// Info describes the provenance of the running binary.
+type Info struct {
+	Version    string `json:"version" yaml:"version"`
+	Commit     string `json:"commit" yaml:"commit"`
+	CommitDate string `json:"commitDate" yaml:"commitDate"`
+	BuildDate  string `json:"buildDate" yaml:"buildDate"`
+	Dirty      bool   `json:"dirty" yaml:"dirty"`
+	GoVersion  string `json:"goVersion" yaml:"goVersion"`
+	Compiler   string `json:"compiler" yaml:"compiler"`
+	Platform   string `json:"platform" yaml:"platform"`
+}
+
+// Get returns the version info for the running binary, preferring
+// ldflags-injected values and falling back to the Go module's embedded
+// VCS information when those weren't set (e.g. under `go install`).
+func Get() Info {
+	info := Info{
+		Version:    version,
+		Commit:     commit,
+		CommitDate: commitDate,
+		BuildDate:  buildDate,
+		Dirty:      dirty == "true",
+		GoVersion:  runtime.Version(),
+		Compiler:   runtime.Compiler,
+		Platform:   fmt.Sprintf("%s/%s", runtime.GOOS, runtime.GOARCH),
+	}
+
+	if bi, ok := debug.ReadBuildInfo(); ok {
+		info = applyVCSSettings(info, bi.Settings, dirty != "")
+	}
+
+	return info
+}
+
+// applyVCSSettings fills in commit, commit date, and dirty from the
+// module's embedded VCS build settings (as seen in
+// debug.BuildInfo.Settings), but only for fields not already set via
+// ldflags. dirtySet reports whether dirty was set via ldflags, since
+// info.Dirty's zero value (false) can't otherwise be told apart from
+// "explicitly set to false".
+func applyVCSSettings(info Info, settings []debug.BuildSetting, dirtySet bool) Info {
+	for _, setting := range settings {
+		switch setting.Key {
+		case "vcs.revision":
+			if info.Commit == "" {
+				info.Commit = setting.Value
+			}
+		case "vcs.time":
+			if info.CommitDate == "" {
+				info.CommitDate = setting.Value
+			}
+		case "vcs.modified":
+			if !dirtySet {
+				info.Dirty = setting.Value == "true"
+			}
+		}
+	}
+	return info
+}
+
+// String renders the info as the multi-line human-readable form used by
+// `go-example --version`. Labels are padded to a common width so the
+// values line up in a column.
+func (i Info) String() string {
+	s := fmt.Sprintf("go-example %s\n", i.Version)
+	s += fmt.Sprintf("  %-12s %s\n", "commit:", orNone(i.Commit))
+	s += fmt.Sprintf("  %-12s %s\n", "commit date:", orNone(i.CommitDate))
+	s += fmt.Sprintf("  %-12s %s\n", "build date:", orNone(i.BuildDate))
+	s += fmt.Sprintf("  %-12s %t\n", "dirty:", i.Dirty)
+	s += fmt.Sprintf("  %-12s %s\n", "go version:", i.GoVersion)
+	s += fmt.Sprintf("  %-12s %s\n", "compiler:", i.Compiler)
+	s += fmt.Sprintf("  %-12s %s\n", "platform:", i.Platform)
+	return s
+}
+
+// Short renders a single-line "name version (commit)" summary, suitable
+// for `--version --output=short` or embedding in other tools' output.
+func (i Info) Short() string {
+	if i.Commit == "" {
+		return fmt.Sprintf("go-example %s", i.Version)
+	}
+	commit := i.Commit
+	if len(commit) > 12 {
+		commit = commit[:12]
+	}
+	return fmt.Sprintf("go-example %s (%s)", i.Version, commit)
+}
+
+func orNone(s string) string {
+	if s == "" {
+		return "none"
+	}
+	return s
+}
@@ -0,0 +1,78 @@
+package version
+
+import (
+	"runtime/debug"
+	"strings"
+	"testing"
+)
+
+func TestApplyVCSSettingsFillsUnsetFields(t *testing.T) {
+	settings := []debug.BuildSetting{
+		{Key: "vcs.revision", Value: "abc123"},
+		{Key: "vcs.time", Value: "2024-01-01T00:00:00Z"},
+		{Key: "vcs.modified", Value: "true"},
+	}
+
+	got := applyVCSSettings(Info{}, settings, false)
+
+	want := Info{Commit: "abc123", CommitDate: "2024-01-01T00:00:00Z", Dirty: true}
+	if got != want {
+		t.Errorf("applyVCSSettings(empty Info) = %+v, want %+v", got, want)
+	}
+}
+
+func TestApplyVCSSettingsLeavesLdflagsValuesAlone(t *testing.T) {
+	settings := []debug.BuildSetting{
+		{Key: "vcs.revision", Value: "fromvcs"},
+		{Key: "vcs.modified", Value: "true"},
+	}
+
+	in := Info{Commit: "fromldflags"}
+	got := applyVCSSettings(in, settings, true)
+
+	if got.Commit != "fromldflags" {
+		t.Errorf("Commit = %q, want ldflags value preserved", got.Commit)
+	}
+	if got.Dirty {
+		t.Errorf("Dirty = true, want ldflags-set dirty=false to be preserved")
+	}
+}
+
+func TestShortTruncatesCommit(t *testing.T) {
+	info := Info{Version: "1.2.3", Commit: "0123456789abcdef"}
+	want := "go-example 1.2.3 (0123456789ab)"
+	if got := info.Short(); got != want {
+		t.Errorf("Short() = %q, want %q", got, want)
+	}
+}
+
+func TestShortWithoutCommit(t *testing.T) {
+	info := Info{Version: "1.2.3"}
+	want := "go-example 1.2.3"
+	if got := info.Short(); got != want {
+		t.Errorf("Short() = %q, want %q", got, want)
+	}
+}
+
+func TestStringAlignsColumns(t *testing.T) {
+	info := Info{
+		Version:    "1.2.3",
+		Commit:     "abc123",
+		CommitDate: "2024-01-01",
+		BuildDate:  "2024-01-02",
+		GoVersion:  "go1.22",
+		Compiler:   "gc",
+		Platform:   "linux/amd64",
+	}
+
+	// Every field line is "  " + a 12-wide padded label + " " + value, so
+	// the value always starts at column 15 regardless of label length.
+	const valueCol = 15
+
+	lines := strings.Split(strings.TrimRight(info.String(), "\n"), "\n")
+	for _, line := range lines[1:] {
+		if len(line) <= valueCol || line[valueCol-1] != ' ' || line[valueCol] == ' ' {
+			t.Errorf("line %q: value doesn't start at column %d", line, valueCol)
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package main
+
+// levenshtein returns the edit distance between a and b, used to suggest
+// a likely-intended subcommand when the user typos a command name.
+func levenshtein(a, b string) int {
+	if a == b {
+		return 0
+	}
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min3(curr[j-1]+1, prev[j]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
+
+func min3(a, b, c int) int {
+	if b < a {
+		a = b
+	}
+	if c < a {
+		a = c
+	}
+	return a
+}
+
+// suggestCommand returns the name of the registered command closest to
+// name, or "" if none is close enough to be worth suggesting.
+func suggestCommand(name string) string {
+	best := ""
+	bestDist := -1
+	for _, cmd := range commands {
+		d := levenshtein(name, cmd.Name)
+		if bestDist == -1 || d < bestDist {
+			best, bestDist = cmd.Name, d
+		}
+	}
+	// Anything further than half the typed command's length (rounded up)
+	// is too much of a stretch to call a typo.
+	if bestDist == -1 || bestDist > (len(name)+1)/2 {
+		return ""
+	}
+	return best
+}
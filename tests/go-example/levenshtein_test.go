@@ -0,0 +1,78 @@
+package main
+
+import "testing"
+
+func TestLevenshtein(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"", "abc", 3},
+		{"abc", "", 3},
+		{"echo", "echo", 0},
+		{"kitten", "sitting", 3},
+		{"aaaa", "aabb", 2},
+		{"aaaa", "abbb", 3},
+	}
+	for _, tt := range tests {
+		if got := levenshtein(tt.a, tt.b); got != tt.want {
+			t.Errorf("levenshtein(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+// withCommands replaces the package-level command registry for the
+// duration of a test.
+func withCommands(t *testing.T, names ...string) {
+	t.Helper()
+	orig := commands
+	commands = nil
+	for _, n := range names {
+		commands = append(commands, &Command{Name: n})
+	}
+	t.Cleanup(func() { commands = orig })
+}
+
+func TestSuggestCommandFindsTypo(t *testing.T) {
+	withCommands(t, "echo", "help", "version")
+
+	if got := suggestCommand("ehco"); got != "echo" {
+		t.Errorf("suggestCommand(%q) = %q, want %q", "ehco", got, "echo")
+	}
+}
+
+func TestSuggestCommandIgnoresUnrelatedInput(t *testing.T) {
+	withCommands(t, "echo", "help", "version")
+
+	if got := suggestCommand("xyz"); got != "" {
+		t.Errorf("suggestCommand(%q) = %q, want no suggestion", "xyz", got)
+	}
+}
+
+func TestSuggestCommandTieKeepsFirstRegistered(t *testing.T) {
+	withCommands(t, "cat", "bat")
+
+	if got := suggestCommand("hat"); got != "cat" {
+		t.Errorf("suggestCommand(%q) = %q, want first-registered tie winner %q", "hat", got, "cat")
+	}
+}
+
+func TestSuggestCommandThreshold(t *testing.T) {
+	withCommands(t, "aaaa")
+
+	if got := suggestCommand("aabb"); got != "aaaa" {
+		t.Errorf("suggestCommand(%q) = %q, want %q (distance 2 is within threshold)", "aabb", got, "aaaa")
+	}
+	if got := suggestCommand("abbb"); got != "" {
+		t.Errorf("suggestCommand(%q) = %q, want no suggestion (distance 3 exceeds threshold)", "abbb", got)
+	}
+}
+
+func TestSuggestCommandNoCommandsRegistered(t *testing.T) {
+	withCommands(t)
+
+	if got := suggestCommand("echo"); got != "" {
+		t.Errorf("suggestCommand with empty registry = %q, want \"\"", got)
+	}
+}
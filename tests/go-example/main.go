@@ -1,30 +1,47 @@
+// Command go-example is a small example CLI, packaged as the test
+// fixture built by go-to-wheel.
 package main
 
 import (
 	"fmt"
 	"os"
-	"strings"
 )
 
 func main() {
-	if len(os.Args) > 1 {
-		if os.Args[1] == "--version" {
-			fmt.Println("go-example 1.0.0")
-			return
-		}
-		if os.Args[1] == "--help" {
-			fmt.Println("Usage: go-example [options] [args...]")
-			fmt.Println("")
-			fmt.Println("Options:")
-			fmt.Println("  --version  Show version")
-			fmt.Println("  --help     Show this help")
-			fmt.Println("  --echo     Echo the remaining arguments")
-			return
-		}
-		if os.Args[1] == "--echo" {
-			fmt.Println(strings.Join(os.Args[2:], " "))
-			return
+	args := os.Args[1:]
+
+	if len(args) == 0 {
+		printUsage(os.Stdout)
+		return
+	}
+
+	switch args[0] {
+	case "-h", "--help":
+		printUsage(os.Stdout)
+		return
+	case "--version":
+		// --version is a well-known alias for the version subcommand, kept
+		// for compatibility; route it through the normal dispatch below so
+		// it gets the same flag parsing (e.g. --output=json).
+		args[0] = "version"
+	}
+
+	name := args[0]
+	cmd := lookupCommand(name)
+	if cmd == nil {
+		fmt.Fprintf(os.Stderr, "go-example: unknown command %q\n", name)
+		if s := suggestCommand(name); s != "" {
+			fmt.Fprintf(os.Stderr, "Did you mean %q?\n", s)
 		}
+		fmt.Fprintln(os.Stderr, `Run "go-example help" for usage.`)
+		os.Exit(1)
+	}
+
+	cmd.Flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage: go-example %s\n", cmd.UsageLine)
+	}
+	if err := cmd.Flag.Parse(args[1:]); err != nil {
+		os.Exit(2)
 	}
-	fmt.Println("Hello from go-example!")
+	cmd.Run(cmd, cmd.Flag.Args())
 }